@@ -21,6 +21,8 @@ var (
 	errInvalidAfterTouchPressure  = errors.New("Invalid after touch pressure")
 	errInvalidSmpteOffset         = errors.New("Invalid SMPTE Offset")
 	errVarInt32Overflow           = errors.New("binary: varint overflows a 32-bit integer")
+	errWriterNoTrack              = errors.New("no track is open, call BeginTrack first")
+	errUnsupportedEvent           = errors.New("unsupported event type")
 )
 
 const (
@@ -140,22 +142,34 @@ func (midi *Midi) ReadMTrk() error {
 	}
 }
 
+// ReadMTrkFormat0 reads the single MTrk chunk of a Format 0 file. A Format 0
+// file has no concurrent tracks, so this reuses the same length/end_pos
+// bookkeeping as Format 1 and leaves event parsing to HasNextEvent/ReadNextEvent.
 func (midi *Midi) ReadMTrkFormat0() error {
-	return errNotImplemented
+	return midi.readMTrkLength()
 }
 
 func (midi *Midi) ReadMTrkFormat1() error {
+	return midi.readMTrkLength()
+}
+
+// ReadMTrkFormat2 reads one independent pattern-track of a Format 2 file.
+// Each MTrk is a self-contained pattern rather than a concurrent part, but the
+// chunk framing is identical to Format 1, so HasNextMTrk/ReadNextMTrk already
+// walk pattern boundaries one at a time without any extra bookkeeping here.
+func (midi *Midi) ReadMTrkFormat2() error {
+	return midi.readMTrkLength()
+}
+
+func (midi *Midi) readMTrkLength() error {
 	if err := binary.Read(midi.buffer, binary.BigEndian, &midi.mtrk.length); err != nil {
 		return err
 	}
 	midi.mtrk.end_pos = midi.buffer.Len() - int(midi.mtrk.length)
+	midi.mtrk.time_pos = 0
 	return nil
 }
 
-func (midi *Midi) ReadMTrkFormat2() error {
-	return errNotImplemented
-}
-
 func (midi *Midi) HasNextEvent() bool {
 	return midi.buffer.Len() > midi.mtrk.end_pos
 }
@@ -166,6 +180,7 @@ func (midi *Midi) ReadNextEvent() (MidiEvent, error) {
 		return nil, err
 	}
 	midi.mtrk.time_pos += midi.event.delta
+	midi.event.absoluteTick = midi.mtrk.time_pos
 	event, err := midi.ReadEvent()
 	return event, err
 }
@@ -243,10 +258,7 @@ func (midi *Midi) ReadSysexEvent() (event MidiEvent, err error) {
 			data = append(data, b)
 		}
 	}
-	event = &SysexEvent{
-		midi.event,
-		data,
-	}
+	event = classifySysexEvent(midi.event, data)
 	return
 }
 
@@ -372,7 +384,7 @@ func (midi *Midi) ReadMetaEvent() (MidiEvent, error) {
 		MetaEventCuePoint,
 		MetaEventProgramName,
 		MetaEventDeviceName:
-		return midi.ReadTextEvent(len)
+		return midi.ReadTextEvent(metaEvent, len)
 	case MetaEventMidiChannel:
 		return nil, errNotImplemented
 	case MetaEventMidiPort:
@@ -491,7 +503,7 @@ func (midi *Midi) ReadTimeSignatureEvent(len uint64) (event MidiEvent, err error
 	return
 }
 
-func (midi *Midi) ReadTextEvent(len uint64) (event MidiEvent, err error) {
+func (midi *Midi) ReadTextEvent(metaType byte, len uint64) (event MidiEvent, err error) {
 	text := ""
 	if len > 0 {
 		var b []byte
@@ -504,6 +516,7 @@ func (midi *Midi) ReadTextEvent(len uint64) (event MidiEvent, err error) {
 
 	event = &TextEvent{
 		midi.event,
+		metaType,
 		text,
 	}
 	return
@@ -523,7 +536,7 @@ func (midi *Midi) ReadTempoEvent(len uint64) (event MidiEvent, err error) {
 	if b3, err = midi.buffer.ReadByte(); err != nil {
 		return
 	}
-	microsecondsPerQuarterNote := int(b1<<16) + int(b2<<8) + int(b3)
+	microsecondsPerQuarterNote := int(b1)<<16 | int(b2)<<8 | int(b3)
 	event = &TempoEvent{
 		midi.event,
 		microsecondsPerQuarterNote,
@@ -547,22 +560,23 @@ func (midi *Midi) ReadBytes(bytes int) ([]byte, error) {
 	return buffer, nil
 }
 
+// ReadUVarInt decodes a standard MIDI variable-length quantity: 7 data bits
+// per byte, most-significant group first, continuation bit (0x80) set on
+// every byte but the last.
 func (midi *Midi) ReadUVarInt() (uint64, error) {
 	var x uint64
-	var s uint
 	for i := 0; ; i++ {
+		if i >= 5 {
+			return 0, errVarInt32Overflow
+		}
 		b, err := midi.buffer.ReadByte()
 		if err != nil {
 			return 0, errVarInt32Overflow
 		}
+		x = x<<7 | uint64(b&0x7f)
 		if b < 0x80 {
-			if i > 5 || i == 5 && b > 1 {
-				return 0, errVarInt32Overflow
-			}
-			return x | uint64(b)<<s, nil
+			return x, nil
 		}
-		x |= uint64(b&0x7f) << s
-		s += 7
 	}
 }
 
@@ -3,12 +3,22 @@ package midi
 import "fmt"
 
 type MidiEvent interface {
+	AbsoluteTick() uint64
 }
 
 type Event struct {
-	delta       uint64
-	commandCode byte
-	channel     uint8
+	delta        uint64
+	commandCode  byte
+	channel      uint8
+	absoluteTick uint64
+}
+
+// AbsoluteTick returns the event's absolute tick position from the start of
+// its track, so callers don't have to re-sum deltas themselves. It is only
+// meaningful for events read from an SMF; events read from a LiveReader or
+// built with a New*Event constructor report 0.
+func (e Event) AbsoluteTick() uint64 {
+	return e.absoluteTick
 }
 
 type NoteOnEvent struct {
@@ -123,7 +133,8 @@ func (e *TimeSignatureEvent) String() string {
 
 type TextEvent struct {
 	Event
-	text string
+	metaType byte
+	text     string
 }
 
 func (e *TextEvent) String() string {
@@ -138,3 +149,55 @@ type TempoEvent struct {
 func (e *TempoEvent) String() string {
 	return fmt.Sprintf("%d, %d, Text_c, %d", e.delta, e.channel, e.microsecondsPerQuarterNote)
 }
+
+func NewNoteOnEvent(channel uint8, key, velocity byte) *NoteOnEvent {
+	return &NoteOnEvent{Event{commandCode: CommandCodeNoteOn, channel: channel}, key, velocity}
+}
+
+func NewNoteOffEvent(channel uint8, key, velocity byte) *NoteOffEvent {
+	return &NoteOffEvent{Event{commandCode: CommandCodeNoteOff, channel: channel}, key, velocity}
+}
+
+func NewControlChangeEvent(channel uint8, key, pressure byte) *ControlChangeEvent {
+	return &ControlChangeEvent{Event{commandCode: CommandCodeControlChange, channel: channel}, key, pressure}
+}
+
+func NewPatchChangeEvent(channel uint8, patch byte) *PatchChangeEvent {
+	return &PatchChangeEvent{Event{commandCode: CommandCodePatchChange, channel: channel}, patch}
+}
+
+func NewAfterTouchEvent(channel uint8, pressure byte) *AfterTouchEvent {
+	return &AfterTouchEvent{Event{commandCode: CommandCodeChannelAfterTouch, channel: channel}, pressure}
+}
+
+func NewPitchWheelEvent(channel uint8, pitch int) *PitchWheelEvent {
+	return &PitchWheelEvent{Event{commandCode: CommandCodePitchWheelChange, channel: channel}, pitch}
+}
+
+func NewSysexEvent(data []byte) *SysexEvent {
+	return &SysexEvent{Event{commandCode: CommandCodeSysex}, data}
+}
+
+func NewSequencerSpecificEvent(data []byte) *SequencerSpecificEvent {
+	return &SequencerSpecificEvent{Event{commandCode: CommandCodeMetaEvent}, data}
+}
+
+func NewSmpteOffsetEvent(hours, minutes, seconds, frames, subFrames byte) *SmpteOffsetEvent {
+	return &SmpteOffsetEvent{Event{commandCode: CommandCodeMetaEvent}, hours, minutes, seconds, frames, subFrames}
+}
+
+func NewKeySignatureEvent(sharpsFlats, majorMinor byte) *KeySignatureEvent {
+	return &KeySignatureEvent{Event{commandCode: CommandCodeMetaEvent}, sharpsFlats, majorMinor}
+}
+
+func NewTimeSignatureEvent(numerator, denominator, ticksInMetronomeClick, no32ndNotesInQuarterNote byte) *TimeSignatureEvent {
+	return &TimeSignatureEvent{Event{commandCode: CommandCodeMetaEvent}, numerator, denominator, ticksInMetronomeClick, no32ndNotesInQuarterNote}
+}
+
+func NewTextEvent(metaType byte, text string) *TextEvent {
+	return &TextEvent{Event{commandCode: CommandCodeMetaEvent}, metaType, text}
+}
+
+func NewTempoEvent(microsecondsPerQuarterNote int) *TempoEvent {
+	return &TempoEvent{Event{commandCode: CommandCodeMetaEvent}, microsecondsPerQuarterNote}
+}
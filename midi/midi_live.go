@@ -0,0 +1,166 @@
+package midi
+
+import (
+	"bufio"
+	"io"
+)
+
+// LiveReader parses a live/real-time MIDI byte stream (serial port, ALSA,
+// RtMidi, USB, ...) instead of an SMF file. It applies the same running-status
+// rules as Midi.ReadEvent but has no MThd/MTrk framing and no
+// delta-times - an event is returned as soon as its bytes have arrived.
+//
+// System Real-Time bytes (0xF8/0xFA/0xFB/0xFC/0xFE/0xFF) can appear
+// interleaved inside any other message without disturbing it, so ReadEvent
+// returns a RealTimeEvent the instant one is seen on the wire - ahead of
+// whatever message it interrupted, which resumes on the next call exactly
+// where it left off.
+type LiveReader struct {
+	in      *bufio.Reader
+	event   Event
+	data    []byte
+	sysex   []byte
+	inSysex bool
+}
+
+func NewLiveReader(in io.Reader) *LiveReader {
+	return &LiveReader{in: bufio.NewReader(in)}
+}
+
+func isSystemRealTime(b byte) bool {
+	switch b {
+	case CommandCodeTimingClock,
+		CommandCodeStartSequence,
+		CommandCodeContinueSequence,
+		CommandCodeStopSequence,
+		CommandCodeAutoSensing,
+		CommandCodeSystemReset:
+		return true
+	default:
+		return false
+	}
+}
+
+// dataBytesNeeded reports how many data bytes a channel/system-common
+// message carries, or -1 if commandCode isn't one of those (Sysex is
+// variable-length and handled separately; System Real-Time never reaches
+// here).
+func dataBytesNeeded(commandCode byte) int {
+	switch commandCode {
+	case CommandCodeNoteOff,
+		CommandCodeNoteOn,
+		CommandCodeKeyAfterTouch,
+		CommandCodeControlChange,
+		CommandCodePitchWheelChange,
+		CommandCodeSongPositionPointer:
+		return 2
+	case CommandCodePatchChange,
+		CommandCodeChannelAfterTouch,
+		CommandCodeMtcQuarterFrame,
+		CommandCodeSongSelect:
+		return 1
+	case CommandCodeTuneRequest:
+		return 0
+	default:
+		return -1
+	}
+}
+
+// ReadEvent reads bytes one at a time until a full event is available,
+// returning it right away. Partially-read channel/system-common messages
+// are resumed across calls via r.event/r.data, so a Real-Time byte seen
+// mid-message can be returned immediately without losing the bytes already
+// read for the message it interrupted.
+func (r *LiveReader) ReadEvent() (MidiEvent, error) {
+	for {
+		b, err := r.in.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		if isSystemRealTime(b) {
+			return &RealTimeEvent{Event{commandCode: b}}, nil
+		}
+
+		if r.inSysex {
+			if b == CommandCodeEox {
+				data := r.sysex
+				r.sysex = nil
+				r.inSysex = false
+				return classifySysexEvent(Event{commandCode: CommandCodeSysex}, data), nil
+			}
+			if b&0x80 == 0 {
+				r.sysex = append(r.sysex, b)
+				continue
+			}
+			// Any other status byte implicitly cancels an unterminated Sysex.
+			r.sysex = nil
+			r.inSysex = false
+		}
+
+		if b&0x80 != 0 {
+			if b == CommandCodeSysex {
+				r.inSysex = true
+				continue
+			}
+			if b&0xF0 == 0xF0 {
+				r.event.commandCode = b
+				r.event.channel = 1
+			} else {
+				r.event.commandCode = b & 0xF0
+				r.event.channel = b&0x0F + 1
+			}
+			r.data = r.data[:0]
+		} else {
+			r.data = append(r.data, b)
+		}
+
+		need := dataBytesNeeded(r.event.commandCode)
+		if need < 0 {
+			return nil, errInvalidCommandCode
+		}
+		if len(r.data) < need {
+			continue
+		}
+
+		event := r.buildEvent()
+		r.data = r.data[:0]
+
+		// System Common messages are not channel voice messages and clear
+		// running status.
+		if r.event.commandCode&0xF0 == 0xF0 {
+			r.event.commandCode = 0
+			r.event.channel = 1
+		}
+
+		return event, nil
+	}
+}
+
+func (r *LiveReader) buildEvent() MidiEvent {
+	d := r.data
+	switch r.event.commandCode {
+	case CommandCodeNoteOn:
+		return &NoteOnEvent{r.event, d[0], d[1]}
+	case CommandCodeNoteOff, CommandCodeKeyAfterTouch:
+		return &NoteOffEvent{r.event, d[0], d[1]}
+	case CommandCodeControlChange:
+		return &ControlChangeEvent{r.event, d[0], d[1]}
+	case CommandCodePatchChange:
+		return &PatchChangeEvent{r.event, d[0]}
+	case CommandCodeChannelAfterTouch:
+		return &AfterTouchEvent{r.event, d[0]}
+	case CommandCodePitchWheelChange:
+		return &PitchWheelEvent{r.event, int(d[0]) | int(d[1])<<7}
+	case CommandCodeMtcQuarterFrame:
+		return &MtcQuarterFrameEvent{r.event, d[0]}
+	case CommandCodeSongPositionPointer:
+		return &SongPositionPointerEvent{r.event, int(d[0]) | int(d[1])<<7}
+	case CommandCodeSongSelect:
+		return &SongSelectEvent{r.event, d[0]}
+	case CommandCodeTuneRequest:
+		return &TuneRequestEvent{r.event}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package midi
+
+import "fmt"
+
+// System Common status bytes. These only appear on a live MIDI stream; an
+// SMF reuses 0xF0-0xFF for Sysex and meta events instead.
+const (
+	CommandCodeMtcQuarterFrame     = 0xF1
+	CommandCodeSongPositionPointer = 0xF2
+	CommandCodeSongSelect          = 0xF3
+	CommandCodeTuneRequest         = 0xF6
+)
+
+// CommandCodeSystemReset is a System Real-Time status byte, live streams
+// only. The same byte value is CommandCodeMetaEvent in an SMF.
+const CommandCodeSystemReset = 0xFF
+
+type MtcQuarterFrameEvent struct {
+	Event
+	data byte
+}
+
+func (e *MtcQuarterFrameEvent) String() string {
+	return fmt.Sprintf("Mtc_quarter_frame_c, %d", e.data)
+}
+
+type SongPositionPointerEvent struct {
+	Event
+	position int
+}
+
+func (e *SongPositionPointerEvent) String() string {
+	return fmt.Sprintf("Song_position_c, %d", e.position)
+}
+
+type SongSelectEvent struct {
+	Event
+	song byte
+}
+
+func (e *SongSelectEvent) String() string {
+	return fmt.Sprintf("Song_select_c, %d", e.song)
+}
+
+type TuneRequestEvent struct {
+	Event
+}
+
+func (e *TuneRequestEvent) String() string {
+	return "Tune_request_c"
+}
+
+// RealTimeEvent is a single-byte System Real-Time message (timing clock,
+// start/continue/stop, active sensing or system reset). It can arrive
+// interleaved inside any other message without disturbing it.
+type RealTimeEvent struct {
+	Event
+}
+
+func (e *RealTimeEvent) String() string {
+	switch e.commandCode {
+	case CommandCodeTimingClock:
+		return "Timing_clock_c"
+	case CommandCodeStartSequence:
+		return "Start_c"
+	case CommandCodeContinueSequence:
+		return "Continue_c"
+	case CommandCodeStopSequence:
+		return "Stop_c"
+	case CommandCodeAutoSensing:
+		return "Active_sensing_c"
+	case CommandCodeSystemReset:
+		return "System_reset_c"
+	default:
+		return "Real_time_c"
+	}
+}
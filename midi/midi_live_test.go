@@ -0,0 +1,50 @@
+package midi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLiveReaderDecodesCenteredPitchWheel(t *testing.T) {
+	// Pitch wheel, channel 1, lsb=0x00, msb=0x40 -> center, 8192.
+	r := NewLiveReader(bytes.NewReader([]byte{0xE0, 0x00, 0x40}))
+
+	event, err := r.ReadEvent()
+	if err != nil {
+		t.Fatalf("ReadEvent: %v", err)
+	}
+	pitchWheel, ok := event.(*PitchWheelEvent)
+	if !ok {
+		t.Fatalf("got %T, want *PitchWheelEvent", event)
+	}
+	if pitchWheel.pitch != 8192 {
+		t.Errorf("pitch = %d, want 8192", pitchWheel.pitch)
+	}
+}
+
+func TestLiveReaderSurfacesRealTimeBeforeInterruptedMessage(t *testing.T) {
+	// NoteOn status, then a timing clock tick arrives before the NoteOn's
+	// own data bytes - the clock tick must come back first, since that's
+	// the order it actually arrived on the wire.
+	r := NewLiveReader(bytes.NewReader([]byte{0x90, 0xF8, 0x3C, 0x64}))
+
+	first, err := r.ReadEvent()
+	if err != nil {
+		t.Fatalf("ReadEvent: %v", err)
+	}
+	if _, ok := first.(*RealTimeEvent); !ok {
+		t.Fatalf("first event = %T, want *RealTimeEvent", first)
+	}
+
+	second, err := r.ReadEvent()
+	if err != nil {
+		t.Fatalf("ReadEvent: %v", err)
+	}
+	noteOn, ok := second.(*NoteOnEvent)
+	if !ok {
+		t.Fatalf("second event = %T, want *NoteOnEvent", second)
+	}
+	if noteOn.key != 0x3C || noteOn.velocity != 0x64 {
+		t.Errorf("key/velocity = %d/%d, want 60/100", noteOn.key, noteOn.velocity)
+	}
+}
@@ -0,0 +1,111 @@
+package midi
+
+import (
+	"sort"
+	"time"
+)
+
+// PlaybackItem is one event from a merged, time-sorted walk across every
+// track of a Midi file.
+type PlaybackItem struct {
+	Tick  uint64
+	Track int
+	Event MidiEvent
+	Wait  time.Duration
+}
+
+type trackEvent struct {
+	tick  uint64
+	track int
+	event MidiEvent
+}
+
+func isMetaEvent(event MidiEvent) bool {
+	switch event.(type) {
+	case *TempoEvent,
+		*TimeSignatureEvent,
+		*KeySignatureEvent,
+		*SmpteOffsetEvent,
+		*TextEvent,
+		*SequencerSpecificEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// PlaybackStream parses every track once and returns a channel of its
+// events merged into a single, time-sorted sequence: (tick, track, event)
+// ordered by absolute tick, meta events before channel events and lower
+// track index first when two events share a tick. Each item's Wait is the
+// wall-clock gap since the previous item, computed from the file's tempo
+// map, so a caller can drive playback with time.Sleep(item.Wait) followed by
+// sending item.Event to an output port. The channel is closed once every
+// track has reached its End-of-Track.
+func (midi *Midi) PlaybackStream() (<-chan PlaybackItem, error) {
+	items, err := midi.buildPlaybackItems()
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan PlaybackItem)
+	go func() {
+		defer close(ch)
+		for _, item := range items {
+			ch <- item
+		}
+	}()
+	return ch, nil
+}
+
+func (midi *Midi) buildPlaybackItems() ([]PlaybackItem, error) {
+	var all []trackEvent
+	for midi.HasNextMTrk() {
+		if err := midi.ReadNextMTrk(); err != nil {
+			return nil, err
+		}
+		track := int(midi.mtrk.track) - 1
+		for midi.HasNextEvent() {
+			event, err := midi.ReadNextEvent()
+			if err != nil {
+				return nil, err
+			}
+			if event == nil {
+				continue
+			}
+			all = append(all, trackEvent{tick: event.AbsoluteTick(), track: track, event: event})
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].tick != all[j].tick {
+			return all[i].tick < all[j].tick
+		}
+		iMeta, jMeta := isMetaEvent(all[i].event), isMetaEvent(all[j].event)
+		if iMeta != jMeta {
+			return iMeta
+		}
+		return all[i].track < all[j].track
+	})
+
+	tempoMap := NewTempoMap(midi.mthd.Division())
+	for _, te := range all {
+		if tempo, ok := te.event.(*TempoEvent); ok {
+			tempoMap.AddTempoEvent(te.tick, tempo)
+		}
+	}
+
+	items := make([]PlaybackItem, len(all))
+	var lastMicros uint64
+	for i, te := range all {
+		micros := tempoMap.TickToMicros(te.tick)
+		items[i] = PlaybackItem{
+			Tick:  te.tick,
+			Track: te.track,
+			Event: te.event,
+			Wait:  time.Duration(micros-lastMicros) * time.Microsecond,
+		}
+		lastMicros = micros
+	}
+	return items, nil
+}
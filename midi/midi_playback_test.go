@@ -0,0 +1,52 @@
+package midi
+
+import "testing"
+
+func TestPlaybackStreamMergesMultipleTracks(t *testing.T) {
+	track1 := []byte{
+		0x00, 0x90, 0x3C, 0x64, // tick 0
+		0x0A, 0x90, 0x40, 0x64, // delta 10, tick 10
+		0x00, 0xFF, 0x2F, 0x00,
+	}
+	track2 := []byte{
+		0x00, 0x90, 0x3C, 0x64, // tick 0
+		0x05, 0x90, 0x40, 0x64, // delta 5, tick 5
+		0x00, 0xFF, 0x2F, 0x00,
+	}
+
+	m := NewMidi(buildSMF(t, track1, track2))
+	if err := m.ReadMThd(); err != nil {
+		t.Fatalf("ReadMThd: %v", err)
+	}
+
+	stream, err := m.PlaybackStream()
+	if err != nil {
+		t.Fatalf("PlaybackStream: %v", err)
+	}
+
+	type ticked struct {
+		tick  uint64
+		track int
+	}
+	var got []ticked
+	for item := range stream {
+		got = append(got, ticked{item.Tick, item.Track})
+	}
+
+	// Track 2's own tick 0/5 must not be shifted by track 1's tick 10, and
+	// ties at the same tick break towards the lower track index.
+	want := []ticked{
+		{0, 0},
+		{0, 1},
+		{5, 1},
+		{10, 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d items, want %d: %v", len(got), len(want), got)
+	}
+	for i, item := range got {
+		if item != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i, item, want[i])
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package midi
+
+import "fmt"
+
+// GMResetEvent is a GM/GM2 System On message (F0 7E 7F 09 01 F7 / F0 7E 7F
+// 09 03 F7), recognized for both Midi and LiveReader Sysex events.
+type GMResetEvent struct {
+	Event
+	generation int // 1 for GM System On, 2 for GM2 System On
+}
+
+func (e *GMResetEvent) String() string {
+	if e.generation == 2 {
+		return "GM2_reset_c"
+	}
+	return "GM_reset_c"
+}
+
+// GSResetEvent is a Roland GS Reset message
+// (F0 41 10 42 12 40 00 7F 00 41 F7).
+type GSResetEvent struct {
+	Event
+}
+
+func (e *GSResetEvent) String() string {
+	return "GS_reset_c"
+}
+
+// XGResetEvent is a Yamaha XG System On message (F0 43 10 4C 00 00 7E 00 F7).
+type XGResetEvent struct {
+	Event
+}
+
+func (e *XGResetEvent) String() string {
+	return "XG_reset_c"
+}
+
+// IdentityRequestEvent is a Universal Non-Real-Time Identity Request
+// (F0 7E <ch> 06 01 F7).
+type IdentityRequestEvent struct {
+	Event
+	deviceID byte
+}
+
+func (e *IdentityRequestEvent) String() string {
+	return fmt.Sprintf("Identity_request_c, %d", e.deviceID)
+}
+
+// IdentityReplyEvent is a Universal Non-Real-Time Identity Reply
+// (F0 7E <ch> 06 02 <manufacturerID> <family LSB/MSB> <member LSB/MSB>
+// <version x4> F7).
+type IdentityReplyEvent struct {
+	Event
+	deviceID       byte
+	manufacturerID byte
+	family         int
+	member         int
+	version        [4]byte
+}
+
+func (e *IdentityReplyEvent) String() string {
+	return fmt.Sprintf("Identity_reply_c, %d, %02X, %d, %d", e.deviceID, e.manufacturerID, e.family, e.member)
+}
+
+// sysexData reconstructs the original Sysex payload (the bytes between F0
+// and the terminating F7) so Writer can re-emit these events unchanged.
+
+func (e *GMResetEvent) sysexData() []byte {
+	if e.generation == 2 {
+		return []byte{0x7E, 0x7F, 0x09, 0x03}
+	}
+	return []byte{0x7E, 0x7F, 0x09, 0x01}
+}
+
+func (e *GSResetEvent) sysexData() []byte {
+	return []byte{0x41, 0x10, 0x42, 0x12, 0x40, 0x00, 0x7F, 0x00, 0x41}
+}
+
+func (e *XGResetEvent) sysexData() []byte {
+	return []byte{0x43, 0x10, 0x4C, 0x00, 0x00, 0x7E, 0x00}
+}
+
+func (e *IdentityRequestEvent) sysexData() []byte {
+	return []byte{0x7E, e.deviceID, 0x06, 0x01}
+}
+
+func (e *IdentityReplyEvent) sysexData() []byte {
+	data := []byte{
+		0x7E, e.deviceID, 0x06, 0x02, e.manufacturerID,
+		byte(e.family & 0x7F), byte((e.family >> 7) & 0x7F),
+		byte(e.member & 0x7F), byte((e.member >> 7) & 0x7F),
+	}
+	return append(data, e.version[:]...)
+}
+
+// classifySysexEvent recognizes the universally-used manufacturer reset and
+// identification payloads, falling back to a generic SysexEvent for anything
+// else. data is the Sysex payload between F0 and the terminating F7.
+func classifySysexEvent(event Event, data []byte) MidiEvent {
+	if len(data) == 4 && data[0] == 0x7E && data[1] == 0x7F && data[2] == 0x09 {
+		switch data[3] {
+		case 0x01:
+			return &GMResetEvent{event, 1}
+		case 0x03:
+			return &GMResetEvent{event, 2}
+		}
+	}
+
+	if len(data) == 9 &&
+		data[0] == 0x41 && data[1] == 0x10 && data[2] == 0x42 && data[3] == 0x12 &&
+		data[4] == 0x40 && data[5] == 0x00 && data[6] == 0x7F && data[7] == 0x00 && data[8] == 0x41 {
+		return &GSResetEvent{event}
+	}
+
+	if len(data) == 7 &&
+		data[0] == 0x43 && data[1] == 0x10 && data[2] == 0x4C &&
+		data[3] == 0x00 && data[4] == 0x00 && data[5] == 0x7E && data[6] == 0x00 {
+		return &XGResetEvent{event}
+	}
+
+	if len(data) == 4 && data[0] == 0x7E && data[2] == 0x06 && data[3] == 0x01 {
+		return &IdentityRequestEvent{event, data[1]}
+	}
+
+	if len(data) == 13 && data[0] == 0x7E && data[2] == 0x06 && data[3] == 0x02 {
+		return &IdentityReplyEvent{
+			event,
+			data[1],
+			data[4],
+			int(data[5]) | int(data[6])<<7,
+			int(data[7]) | int(data[8])<<7,
+			[4]byte{data[9], data[10], data[11], data[12]},
+		}
+	}
+
+	return &SysexEvent{event, data}
+}
@@ -0,0 +1,41 @@
+package midi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriterRoundTripsClassifiedSysex(t *testing.T) {
+	gmReset := []byte{0xF0, 0x7E, 0x7F, 0x09, 0x01, 0xF7}
+
+	m := NewMidi(bytes.NewBuffer(gmReset))
+	event, err := m.ReadEvent()
+	if err != nil {
+		t.Fatalf("ReadEvent: %v", err)
+	}
+	if _, ok := event.(*GMResetEvent); !ok {
+		t.Fatalf("got %T, want *GMResetEvent", event)
+	}
+
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf)
+	w.BeginTrack()
+	if err := w.WriteEvent(0, event); err != nil {
+		t.Fatalf("WriteEvent: %v", err)
+	}
+	if err := w.EndTrack(); err != nil {
+		t.Fatalf("EndTrack: %v", err)
+	}
+
+	m2 := NewMidi(buf)
+	if err := m2.ReadMTrk(); err != nil {
+		t.Fatalf("ReadMTrk: %v", err)
+	}
+	roundTripped, err := m2.ReadNextEvent()
+	if err != nil {
+		t.Fatalf("ReadNextEvent: %v", err)
+	}
+	if _, ok := roundTripped.(*GMResetEvent); !ok {
+		t.Fatalf("round-tripped event is %T, want *GMResetEvent", roundTripped)
+	}
+}
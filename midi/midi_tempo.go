@@ -0,0 +1,116 @@
+package midi
+
+import "time"
+
+// Division is the decoded form of Mthd.division: either ticks-per-quarter-
+// note (PPQ) or an SMPTE frame rate with a ticks-per-frame resolution.
+type Division interface {
+	isDivision()
+}
+
+// TicksPerQuarter is the PPQ division mode: ticks per quarter note.
+type TicksPerQuarter int
+
+func (TicksPerQuarter) isDivision() {}
+
+// SmpteDivision is the SMPTE division mode. Tick resolution is fixed by the
+// frame rate and ticks-per-frame, independent of tempo.
+type SmpteDivision struct {
+	fps           float64
+	ticksPerFrame int
+}
+
+func (SmpteDivision) isDivision() {}
+
+func (d SmpteDivision) FPS() float64 {
+	return d.fps
+}
+
+func (d SmpteDivision) TicksPerFrame() int {
+	return d.ticksPerFrame
+}
+
+// Division decodes Mthd.division per the SMF spec: a positive value is
+// ticks-per-quarter-note, a negative value packs an SMPTE frame rate
+// (-24/-25/-29/-30) into the upper byte and ticks-per-frame into the lower
+// byte.
+func (mthd Mthd) Division() Division {
+	if mthd.division >= 0 {
+		return TicksPerQuarter(mthd.division)
+	}
+	upperByte := int8(mthd.division >> 8)
+	lowerByte := byte(mthd.division)
+	var fps float64
+	switch upperByte {
+	case -24:
+		fps = 24
+	case -25:
+		fps = 25
+	case -29:
+		fps = 29.97
+	case -30:
+		fps = 30
+	}
+	return SmpteDivision{fps: fps, ticksPerFrame: int(lowerByte)}
+}
+
+const defaultMicrosecondsPerQuarterNote = 500000 // 120 BPM, the SMF default absent a tempo event
+
+type tempoChange struct {
+	tick                       uint64
+	micros                     uint64
+	microsecondsPerQuarterNote int
+}
+
+// TempoMap collects every TempoEvent across a file's tracks by absolute tick
+// position and converts tick positions to wall-clock time. Under PPQ it
+// integrates the piecewise-constant tempo across the segments delimited by
+// tempo changes; under an SMPTE division tempo is fixed by the frame rate
+// and tempo events are ignored.
+type TempoMap struct {
+	division Division
+	changes  []tempoChange
+}
+
+func NewTempoMap(division Division) *TempoMap {
+	return &TempoMap{
+		division: division,
+		changes:  []tempoChange{{tick: 0, micros: 0, microsecondsPerQuarterNote: defaultMicrosecondsPerQuarterNote}},
+	}
+}
+
+// AddTempoEvent records a TempoEvent at its absolute tick position. Events
+// must be added in non-decreasing tick order. Under an SMPTE division they
+// are recorded but never consulted by TickToMicros/TickToDuration.
+func (tm *TempoMap) AddTempoEvent(tick uint64, event *TempoEvent) {
+	tm.changes = append(tm.changes, tempoChange{
+		tick:                       tick,
+		micros:                     tm.TickToMicros(tick),
+		microsecondsPerQuarterNote: event.microsecondsPerQuarterNote,
+	})
+}
+
+func (tm *TempoMap) TickToMicros(tick uint64) uint64 {
+	switch d := tm.division.(type) {
+	case SmpteDivision:
+		ticksPerSecond := d.fps * float64(d.ticksPerFrame)
+		return uint64(float64(tick) / ticksPerSecond * 1e6)
+	case TicksPerQuarter:
+		change := tm.changes[0]
+		for _, c := range tm.changes {
+			if c.tick > tick {
+				break
+			}
+			change = c
+		}
+		elapsedTicks := tick - change.tick
+		elapsedMicros := elapsedTicks * uint64(change.microsecondsPerQuarterNote) / uint64(d)
+		return change.micros + elapsedMicros
+	default:
+		return 0
+	}
+}
+
+func (tm *TempoMap) TickToDuration(tick uint64) time.Duration {
+	return time.Duration(tm.TickToMicros(tick)) * time.Microsecond
+}
@@ -0,0 +1,59 @@
+package midi
+
+import "testing"
+
+func TestReadTempoEventDecodes24BitValue(t *testing.T) {
+	track := []byte{
+		0x00, 0xFF, 0x51, 0x03, 0x07, 0xA1, 0x20, // 500000us = 120 BPM
+		0x00, 0xFF, 0x2F, 0x00,
+	}
+
+	m := NewMidi(buildSMF(t, track))
+	if err := m.ReadMThd(); err != nil {
+		t.Fatalf("ReadMThd: %v", err)
+	}
+	if err := m.ReadNextMTrk(); err != nil {
+		t.Fatalf("ReadNextMTrk: %v", err)
+	}
+
+	event, err := m.ReadNextEvent()
+	if err != nil {
+		t.Fatalf("ReadNextEvent: %v", err)
+	}
+	tempo, ok := event.(*TempoEvent)
+	if !ok {
+		t.Fatalf("got %T, want *TempoEvent", event)
+	}
+	if tempo.microsecondsPerQuarterNote != 500000 {
+		t.Errorf("microsecondsPerQuarterNote = %d, want 500000", tempo.microsecondsPerQuarterNote)
+	}
+}
+
+func TestTickToMicrosUsesTempoMap(t *testing.T) {
+	tm := NewTempoMap(TicksPerQuarter(96))
+	// 96 ticks per quarter at the default 500000us/quarter = 120 BPM.
+	if got := tm.TickToMicros(96); got != 500000 {
+		t.Errorf("TickToMicros(96) = %d, want 500000", got)
+	}
+
+	tm.AddTempoEvent(96, &TempoEvent{microsecondsPerQuarterNote: 1000000}) // drop to 60 BPM at tick 96
+	if got := tm.TickToMicros(96 + 48); got != 500000+500000 {
+		t.Errorf("TickToMicros(144) = %d, want %d", got, 500000+500000)
+	}
+}
+
+func TestMthdDivisionDecodesSmpte(t *testing.T) {
+	mthd := Mthd{division: -6360} // 0xE728: -25 (fps) / 40 (ticksPerFrame)
+
+	division := mthd.Division()
+	smpte, ok := division.(SmpteDivision)
+	if !ok {
+		t.Fatalf("got %T, want SmpteDivision", division)
+	}
+	if smpte.FPS() != 25 {
+		t.Errorf("FPS() = %v, want 25", smpte.FPS())
+	}
+	if smpte.TicksPerFrame() != 40 {
+		t.Errorf("TicksPerFrame() = %d, want 40", smpte.TicksPerFrame())
+	}
+}
@@ -0,0 +1,135 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// buildSMF assembles a minimal Format 1 SMF with the given raw MTrk bodies,
+// writing the MThd/MTrk framing by hand so the test does not depend on Writer.
+func buildSMF(t *testing.T, tracks ...[]byte) *bytes.Buffer {
+	t.Helper()
+	return buildSMFFormat(t, 1, tracks...)
+}
+
+// buildSMFFormat is buildSMF with an explicit format, for tests that need
+// Format 0 or Format 2 framing instead of the default Format 1.
+func buildSMFFormat(t *testing.T, format int16, tracks ...[]byte) *bytes.Buffer {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	buf.WriteString("MThd")
+	binary.Write(buf, binary.BigEndian, int32(6))
+	binary.Write(buf, binary.BigEndian, format)
+	binary.Write(buf, binary.BigEndian, int16(len(tracks)))
+	binary.Write(buf, binary.BigEndian, int16(96))
+	for _, track := range tracks {
+		buf.WriteString("MTrk")
+		binary.Write(buf, binary.BigEndian, int32(len(track)))
+		buf.Write(track)
+	}
+	return buf
+}
+
+func TestAbsoluteTickResetsPerTrack(t *testing.T) {
+	track1 := []byte{
+		0x00, 0x90, 0x3C, 0x64,
+		0x0A, 0x90, 0x40, 0x64, // delta 10, tick 10
+		0x00, 0xFF, 0x2F, 0x00,
+	}
+	track2 := []byte{
+		0x00, 0x90, 0x3C, 0x64,
+		0x05, 0x90, 0x40, 0x64, // delta 5, tick 5 - must not carry over track1's tick 10
+		0x00, 0xFF, 0x2F, 0x00,
+	}
+
+	m := NewMidi(buildSMF(t, track1, track2))
+	if err := m.ReadMThd(); err != nil {
+		t.Fatalf("ReadMThd: %v", err)
+	}
+
+	var ticks []uint64
+	for m.HasNextMTrk() {
+		if err := m.ReadNextMTrk(); err != nil {
+			t.Fatalf("ReadNextMTrk: %v", err)
+		}
+		for m.HasNextEvent() {
+			event, err := m.ReadNextEvent()
+			if err != nil {
+				t.Fatalf("ReadNextEvent: %v", err)
+			}
+			if event == nil {
+				continue
+			}
+			ticks = append(ticks, event.AbsoluteTick())
+		}
+	}
+
+	want := []uint64{0, 10, 0, 5}
+	if len(ticks) != len(want) {
+		t.Fatalf("got %d events, want %d: %v", len(ticks), len(want), ticks)
+	}
+	for i, tick := range ticks {
+		if tick != want[i] {
+			t.Errorf("event %d: AbsoluteTick() = %d, want %d", i, tick, want[i])
+		}
+	}
+}
+
+func readAllNoteOnKeys(t *testing.T, buf *bytes.Buffer) []byte {
+	t.Helper()
+	m := NewMidi(buf)
+	if err := m.ReadMThd(); err != nil {
+		t.Fatalf("ReadMThd: %v", err)
+	}
+
+	var keys []byte
+	for m.HasNextMTrk() {
+		if err := m.ReadNextMTrk(); err != nil {
+			t.Fatalf("ReadNextMTrk: %v", err)
+		}
+		for m.HasNextEvent() {
+			event, err := m.ReadNextEvent()
+			if err != nil {
+				t.Fatalf("ReadNextEvent: %v", err)
+			}
+			if noteOn, ok := event.(*NoteOnEvent); ok {
+				keys = append(keys, noteOn.key)
+			}
+		}
+	}
+	return keys
+}
+
+func TestReadMTrkFormat0ParsesSingleTrack(t *testing.T) {
+	track := []byte{
+		0x00, 0x90, 0x3C, 0x64,
+		0x00, 0x90, 0x40, 0x64,
+		0x00, 0xFF, 0x2F, 0x00,
+	}
+
+	keys := readAllNoteOnKeys(t, buildSMFFormat(t, 0, track))
+
+	want := []byte{0x3C, 0x40}
+	if len(keys) != len(want) || keys[0] != want[0] || keys[1] != want[1] {
+		t.Fatalf("got keys %v, want %v", keys, want)
+	}
+}
+
+func TestReadMTrkFormat2ParsesIndependentPatterns(t *testing.T) {
+	pattern1 := []byte{
+		0x00, 0x90, 0x3C, 0x64,
+		0x00, 0xFF, 0x2F, 0x00,
+	}
+	pattern2 := []byte{
+		0x00, 0x90, 0x50, 0x64,
+		0x00, 0xFF, 0x2F, 0x00,
+	}
+
+	keys := readAllNoteOnKeys(t, buildSMFFormat(t, 2, pattern1, pattern2))
+
+	want := []byte{0x3C, 0x50}
+	if len(keys) != len(want) || keys[0] != want[0] || keys[1] != want[1] {
+		t.Fatalf("got keys %v, want %v", keys, want)
+	}
+}
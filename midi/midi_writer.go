@@ -0,0 +1,200 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// Writer emits a standard MIDI file, mirroring the chunk/track structure
+// that Midi reads. Call WriteMThd once, then BeginTrack/WriteEvent*/EndTrack
+// for each MTrk.
+type Writer struct {
+	out           io.Writer
+	track         *bytes.Buffer
+	runningStatus bool
+	lastStatus    byte
+}
+
+func NewWriter(out io.Writer) *Writer {
+	return &Writer{out: out}
+}
+
+// UseRunningStatus enables running-status compression: a channel event with
+// the same status byte as the previous one omits the repeated status byte.
+func (w *Writer) UseRunningStatus(enabled bool) {
+	w.runningStatus = enabled
+}
+
+func (w *Writer) WriteMThd(format, tracks, division int16) error {
+	if _, err := w.out.Write([]byte("MThd")); err != nil {
+		return err
+	}
+	if err := binary.Write(w.out, binary.BigEndian, int32(6)); err != nil {
+		return err
+	}
+	if err := binary.Write(w.out, binary.BigEndian, format); err != nil {
+		return err
+	}
+	if err := binary.Write(w.out, binary.BigEndian, tracks); err != nil {
+		return err
+	}
+	return binary.Write(w.out, binary.BigEndian, division)
+}
+
+func (w *Writer) BeginTrack() {
+	w.track = new(bytes.Buffer)
+	w.lastStatus = 0
+}
+
+func (w *Writer) EndTrack() error {
+	if w.track == nil {
+		return errWriterNoTrack
+	}
+	if err := w.writeUVarInt(0); err != nil {
+		return err
+	}
+	if err := w.writeMeta(MetaEventEndTrack, nil); err != nil {
+		return err
+	}
+
+	if _, err := w.out.Write([]byte("MTrk")); err != nil {
+		return err
+	}
+	if err := binary.Write(w.out, binary.BigEndian, int32(w.track.Len())); err != nil {
+		return err
+	}
+	_, err := w.out.Write(w.track.Bytes())
+	w.track = nil
+	return err
+}
+
+func (w *Writer) WriteEvent(delta uint64, ev MidiEvent) error {
+	if w.track == nil {
+		return errWriterNoTrack
+	}
+	if err := w.writeUVarInt(delta); err != nil {
+		return err
+	}
+
+	switch e := ev.(type) {
+	case *NoteOnEvent:
+		return w.writeChannelEvent2(e.commandCode, e.channel, e.key, e.velocity)
+	case *NoteOffEvent:
+		return w.writeChannelEvent2(e.commandCode, e.channel, e.key, e.velocity)
+	case *ControlChangeEvent:
+		return w.writeChannelEvent2(e.commandCode, e.channel, e.key, e.pressure)
+	case *PatchChangeEvent:
+		return w.writeChannelEvent1(e.commandCode, e.channel, e.patch)
+	case *AfterTouchEvent:
+		return w.writeChannelEvent1(e.commandCode, e.channel, e.pressure)
+	case *PitchWheelEvent:
+		lsb := byte(e.pitch & 0x7F)
+		msb := byte((e.pitch >> 7) & 0x7F)
+		return w.writeChannelEvent2(e.commandCode, e.channel, lsb, msb)
+	case *SysexEvent:
+		return w.writeSysexEvent(e.data)
+	case *GMResetEvent:
+		return w.writeSysexEvent(e.sysexData())
+	case *GSResetEvent:
+		return w.writeSysexEvent(e.sysexData())
+	case *XGResetEvent:
+		return w.writeSysexEvent(e.sysexData())
+	case *IdentityRequestEvent:
+		return w.writeSysexEvent(e.sysexData())
+	case *IdentityReplyEvent:
+		return w.writeSysexEvent(e.sysexData())
+	case *TempoEvent:
+		return w.writeTempoEvent(e.microsecondsPerQuarterNote)
+	case *SmpteOffsetEvent:
+		return w.writeMeta(MetaEventSmpteOffset, []byte{e.hours, e.minutes, e.seconds, e.frames, e.subFrames})
+	case *TimeSignatureEvent:
+		return w.writeMeta(MetaEventTimeSignature, []byte{e.numerator, e.denominator, e.ticksInMetronomeClick, e.no32ndNotesInQuarterNote})
+	case *KeySignatureEvent:
+		return w.writeMeta(MetaEventKeySignature, []byte{e.sharpsFlats, e.majorMinor})
+	case *TextEvent:
+		return w.writeMeta(e.metaType, []byte(e.text))
+	case *SequencerSpecificEvent:
+		return w.writeMeta(MetaEventSequencerSpecific, e.data)
+	default:
+		return errUnsupportedEvent
+	}
+}
+
+func (w *Writer) writeStatus(commandCode byte, channel uint8) error {
+	status := commandCode
+	if commandCode&0xF0 != 0xF0 {
+		status |= (channel - 1) & 0x0F
+	}
+	if w.runningStatus && status == w.lastStatus && status&0xF0 != 0xF0 {
+		return nil
+	}
+	w.lastStatus = status
+	return w.track.WriteByte(status)
+}
+
+func (w *Writer) writeChannelEvent1(commandCode byte, channel uint8, b byte) error {
+	if err := w.writeStatus(commandCode, channel); err != nil {
+		return err
+	}
+	return w.track.WriteByte(b)
+}
+
+func (w *Writer) writeChannelEvent2(commandCode byte, channel uint8, b1, b2 byte) error {
+	if err := w.writeStatus(commandCode, channel); err != nil {
+		return err
+	}
+	if err := w.track.WriteByte(b1); err != nil {
+		return err
+	}
+	return w.track.WriteByte(b2)
+}
+
+func (w *Writer) writeSysexEvent(data []byte) error {
+	w.lastStatus = 0
+	if err := w.track.WriteByte(CommandCodeSysex); err != nil {
+		return err
+	}
+	if _, err := w.track.Write(data); err != nil {
+		return err
+	}
+	return w.track.WriteByte(CommandCodeEox)
+}
+
+func (w *Writer) writeTempoEvent(microsecondsPerQuarterNote int) error {
+	data := []byte{
+		byte(microsecondsPerQuarterNote >> 16),
+		byte(microsecondsPerQuarterNote >> 8),
+		byte(microsecondsPerQuarterNote),
+	}
+	return w.writeMeta(MetaEventSetTempo, data)
+}
+
+func (w *Writer) writeMeta(metaType byte, data []byte) error {
+	w.lastStatus = 0
+	if err := w.track.WriteByte(CommandCodeMetaEvent); err != nil {
+		return err
+	}
+	if err := w.track.WriteByte(metaType); err != nil {
+		return err
+	}
+	if err := w.writeUVarInt(uint64(len(data))); err != nil {
+		return err
+	}
+	_, err := w.track.Write(data)
+	return err
+}
+
+func (w *Writer) writeUVarInt(x uint64) error {
+	var buf [10]byte
+	i := len(buf) - 1
+	buf[i] = byte(x & 0x7F)
+	x >>= 7
+	for x > 0 {
+		i--
+		buf[i] = byte(x&0x7F) | 0x80
+		x >>= 7
+	}
+	_, err := w.track.Write(buf[i:])
+	return err
+}
@@ -0,0 +1,103 @@
+package midi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriterRoundTripsLargeDelta(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf)
+	if err := w.WriteMThd(0, 1, 96); err != nil {
+		t.Fatalf("WriteMThd: %v", err)
+	}
+	w.BeginTrack()
+	if err := w.WriteEvent(480, NewNoteOnEvent(1, 60, 100)); err != nil {
+		t.Fatalf("WriteEvent: %v", err)
+	}
+	if err := w.EndTrack(); err != nil {
+		t.Fatalf("EndTrack: %v", err)
+	}
+
+	m := NewMidi(buf)
+	if err := m.ReadMThd(); err != nil {
+		t.Fatalf("ReadMThd: %v", err)
+	}
+	if err := m.ReadNextMTrk(); err != nil {
+		t.Fatalf("ReadNextMTrk: %v", err)
+	}
+
+	event, err := m.ReadNextEvent()
+	if err != nil {
+		t.Fatalf("ReadNextEvent: %v", err)
+	}
+	noteOn, ok := event.(*NoteOnEvent)
+	if !ok {
+		t.Fatalf("got %T, want *NoteOnEvent", event)
+	}
+	if noteOn.delta != 480 {
+		t.Errorf("delta = %d, want 480", noteOn.delta)
+	}
+	if noteOn.key != 60 || noteOn.velocity != 100 {
+		t.Errorf("key/velocity = %d/%d, want 60/100", noteOn.key, noteOn.velocity)
+	}
+}
+
+func TestWriterRunningStatusOmitsRepeatedStatusByte(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf)
+	w.UseRunningStatus(true)
+	if err := w.WriteMThd(0, 1, 96); err != nil {
+		t.Fatalf("WriteMThd: %v", err)
+	}
+	w.BeginTrack()
+	if err := w.WriteEvent(0, NewNoteOnEvent(1, 60, 100)); err != nil {
+		t.Fatalf("WriteEvent: %v", err)
+	}
+	if err := w.WriteEvent(10, NewNoteOnEvent(1, 64, 90)); err != nil {
+		t.Fatalf("WriteEvent: %v", err)
+	}
+	if err := w.EndTrack(); err != nil {
+		t.Fatalf("EndTrack: %v", err)
+	}
+
+	raw := buf.Bytes()
+	statusBytes := 0
+	for _, b := range raw {
+		if b == 0x90 {
+			statusBytes++
+		}
+	}
+	if statusBytes != 1 {
+		t.Fatalf("found %d occurrences of the NoteOn status byte on the wire, want 1 (running status should omit the second)", statusBytes)
+	}
+
+	m := NewMidi(bytes.NewBuffer(raw))
+	if err := m.ReadMThd(); err != nil {
+		t.Fatalf("ReadMThd: %v", err)
+	}
+	if err := m.ReadNextMTrk(); err != nil {
+		t.Fatalf("ReadNextMTrk: %v", err)
+	}
+
+	first, err := m.ReadNextEvent()
+	if err != nil {
+		t.Fatalf("ReadNextEvent: %v", err)
+	}
+	firstNoteOn, ok := first.(*NoteOnEvent)
+	if !ok || firstNoteOn.key != 60 || firstNoteOn.velocity != 100 {
+		t.Fatalf("first event = %#v, want NoteOnEvent{key:60, velocity:100}", first)
+	}
+
+	second, err := m.ReadNextEvent()
+	if err != nil {
+		t.Fatalf("ReadNextEvent: %v", err)
+	}
+	secondNoteOn, ok := second.(*NoteOnEvent)
+	if !ok || secondNoteOn.key != 64 || secondNoteOn.velocity != 90 {
+		t.Fatalf("second event = %#v, want NoteOnEvent{key:64, velocity:90}", second)
+	}
+	if secondNoteOn.delta != 10 {
+		t.Errorf("second event delta = %d, want 10", secondNoteOn.delta)
+	}
+}